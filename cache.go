@@ -1,191 +1,206 @@
 package clockpro
 
-import "sync"
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
 
-// Cache implements the CLOCK-Pro cache replacement algorithm using generics.
+// Cache implements a pluggable cache replacement policy using generics.
 //
 //	K must be comparable so it is valid as a map key.
 //	V can be any type.
 //
 // All operations are safe for concurrent access.
+//
+// By default a Cache runs CLOCK-Pro (state), which owns its own pageMap,
+// cost accounting, TTL bookkeeping, and lifecycle event queue, exactly as
+// before WithPolicy existed. Passing WithPolicy(SIEVE) or WithPolicy(S3FIFO)
+// switches to a simpler Policy implementation (see policy.go); that path
+// keeps its pages in altPages and supports Get, Put, SetSize, TTL, and
+// OnEvict, but not cost-weighting or OnPromote/OnDemote, which are
+// CLOCK-Pro specific.
 type Cache[K comparable, V any] struct {
-	mu    sync.RWMutex
-	state *clockProState[K, V]
+	mu sync.RWMutex
+
+	state    *clockProState[K, V] // non-nil when policy is ClockPro (the default)
+	policy   Policy[K, V]         // non-nil for any other policy
+	altPages map[K]*page[K, V]    // pageMap for the alt-policy path
+
+	defaultTTL time.Duration   // applied by Put/PutWithCost; 0 means no expiration
+	loaders    callGroup[K, V] // coalesces concurrent GetOrLoad misses
+
+	onEvict   func(K, V, EvictReason)
+	onPromote func(K, V)
+	onDemote  func(K, V)
+
+	// altHits, altMisses, and altEvictions back Stats for the SIEVE and
+	// S3FIFO policies, which have no hot/cold split of their own to report.
+	altHits      atomic.Int64
+	altMisses    atomic.Int64
+	altEvictions atomic.Int64
 }
 
-// New returns a new cache with the provided capacity. A non-positive size is
-// clamped to 1.
-func New[K comparable, V any](size int) *Cache[K, V] {
-	return &Cache[K, V]{
-		state: newClockProState[K, V](size),
+// New returns a new cache with the provided item capacity. A non-positive
+// size is clamped to 1. Put charges every entry a cost of 1, so capacity is
+// the number of resident items unless PutWithCost assigns some entry a
+// different cost. WithPolicy selects an eviction algorithm other
+// than the default, CLOCK-Pro.
+func New[K comparable, V any](size int, opts ...Option) *Cache[K, V] {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
 	}
+
+	switch o.policy {
+	case SIEVE:
+		return &Cache[K, V]{policy: newSievePolicy[K, V](size), altPages: make(map[K]*page[K, V])}
+	case S3FIFO:
+		return &Cache[K, V]{policy: newS3FIFOPolicy[K, V](size), altPages: make(map[K]*page[K, V])}
+	default:
+		return &Cache[K, V]{state: newClockProState[K, V](int64(size), nil)}
+	}
+}
+
+// NewWithCost returns a cache whose capacity is measured in the weight
+// coster assigns each key/value pair rather than item count. This suits
+// entries of wildly different sizes, e.g. byte buffers of varying length,
+// where a flat item cap would over- or under-commit memory.
+func NewWithCost[K comparable, V any](maxCost int64, coster func(K, V) int64) *Cache[K, V] {
+	return &Cache[K, V]{state: newClockProState[K, V](maxCost, coster)}
 }
 
-// Get retrieves a value from the cache and marks it as accessed
+// Get retrieves a value from the cache and marks it as accessed. An entry
+// past its TTL is treated as a miss.
 func (c *Cache[K, V]) Get(key K) (V, bool) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	if c.state != nil {
+		v, ok := c.state.get(key)
+		evicts, promotes, demotes := c.state.takeEvents()
+		c.mu.Unlock()
+
+		c.fireEvents(evicts, promotes, demotes)
+		return v, ok
+	}
 
-	p, exists := c.state.pageMap[key]
+	p, exists := c.altPages[key]
 	if !exists {
+		c.mu.Unlock()
+		c.altMisses.Add(1)
 		var zero V
 		return zero, false
 	}
-
-	// Update access metadata based on current state
-	switch p.state {
-	case stateHot:
-		p.ref = true
-
-	case stateColdResident:
-		if p.test {
-			// Cold test page hit - promote to hot
-			p.state = stateHot
-			p.test = false
-			p.ref = true
-			c.state.coldList.remove(p)
-			c.state.hotList.insert(p)
-			p.listID = 0
-
-			// Adjust capacity
-			if c.state.hotList.size > c.state.hotCapacity {
-				c.state.adaptHotCapacity(false)
-			}
-		} else {
-			// Regular cold page hit
-			p.ref = true
-		}
-
-	case stateCold:
-		// Non-resident cold page hit
-		if p.test {
-			// This was a test page - adjust capacity and promote
-			c.state.adaptHotCapacity(true)
-		}
-
-		// Remove from metadata list and make resident
-		c.state.metaList.remove(p)
-
-		// Make space if needed
-		c.state.makeSpace()
-
-		// Add as hot page
-		p.state = stateHot
-		p.test = false
-		p.ref = true
-		c.state.hotList.insert(p)
-		p.listID = 0
-
-		// Note: value was zero value for non-resident, caller needs to reload
+	if p.expiresAt != 0 && time.Now().UnixNano() >= p.expiresAt {
+		old := *p
+		c.policy.Remove(p)
+		delete(c.altPages, key)
+		c.mu.Unlock()
+
+		c.altMisses.Add(1)
+		c.fireEvicted([]*page[K, V]{&old}, ReasonExpire)
 		var zero V
 		return zero, false
 	}
-
-	return p.value, true
+	c.policy.Access(p)
+	v := p.value
+	c.mu.Unlock()
+	c.altHits.Add(1)
+	return v, true
 }
 
-// Put inserts or updates a key-value pair in the cache
+// Put inserts or updates a key-value pair in the cache, charging it the cost
+// reported by the cache's coster (1 for a plain New cache, ignored by the
+// SIEVE and S3FIFO policies) and the TTL set by SetDefaultTTL, if any.
 func (c *Cache[K, V]) Put(key K, value V) {
+	if c.state == nil {
+		c.putAlt(key, value, expiryFor(c.defaultTTL))
+		return
+	}
+
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	c.state.put(key, value, c.state.costOf(key, value), expiryFor(c.defaultTTL))
+	evicts, promotes, demotes := c.state.takeEvents()
+	c.mu.Unlock()
 
-	// Check if key already exists
-	if p, exists := c.state.pageMap[key]; exists {
-		// Update existing entry
-		p.value = value
+	c.fireEvents(evicts, promotes, demotes)
+}
 
-		// Update access pattern as in Get
-		switch p.state {
-		case stateHot:
-			p.ref = true
-
-		case stateColdResident:
-			if p.test {
-				// Promote to hot
-				p.state = stateHot
-				p.test = false
-				p.ref = true
-				c.state.coldList.remove(p)
-				c.state.hotList.insert(p)
-				p.listID = 0
-
-				if c.state.hotList.size > c.state.hotCapacity {
-					c.state.adaptHotCapacity(false)
-				}
-			} else {
-				p.ref = true
-			}
-
-		case stateCold:
-			// Promote non-resident to hot
-			if p.test {
-				c.state.adaptHotCapacity(true)
-			}
-
-			c.state.metaList.remove(p)
-			c.state.makeSpace()
-
-			p.state = stateHot
-			p.test = false
-			p.ref = true
-			c.state.hotList.insert(p)
-			p.listID = 0
-		}
+// PutWithCost inserts or updates a key-value pair with an explicit cost,
+// overriding whatever the cache's coster would have computed. Useful when
+// the cost is cheaper to compute at the call site than inside a coster
+// closure (e.g. the caller already knows a buffer's length). Cost-weighting
+// is CLOCK-Pro specific; cost is ignored by SIEVE and S3FIFO caches.
+func (c *Cache[K, V]) PutWithCost(key K, value V, cost int64) {
+	if c.state == nil {
+		c.putAlt(key, value, expiryFor(c.defaultTTL))
 		return
 	}
 
-	// New entry - make space first
-	c.state.makeSpace()
-
-	// Create new page and add to cold list initially
-	newPage := &page[K, V]{
-		key:    key,
-		value:  value,
-		state:  stateColdResident,
-		ref:    false,
-		test:   true, // new pages start as test pages
-		listID: 1,
-	}
+	c.mu.Lock()
+	c.state.put(key, value, cost, expiryFor(c.defaultTTL))
+	evicts, promotes, demotes := c.state.takeEvents()
+	c.mu.Unlock()
 
-	c.state.pageMap[key] = newPage
-	c.state.coldList.insert(newPage)
+	c.fireEvents(evicts, promotes, demotes)
 }
 
-// SetSize adjusts the total capacity of the cache
-func (c *Cache[K, V]) SetSize(size int) {
+// putAlt is Put/PutWithCost's path for the SIEVE and S3FIFO policies.
+func (c *Cache[K, V]) putAlt(key K, value V, expiresAt int64) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	if p, exists := c.altPages[key]; exists {
+		old := *p
+		p.value = value
+		p.expiresAt = expiresAt
+		c.policy.Access(p)
+		c.mu.Unlock()
 
-	if size <= 0 {
-		size = 1
+		c.fireEvicted([]*page[K, V]{&old}, ReasonReplace)
+		return
 	}
 
-	oldCapacity := c.state.capacity
-	c.state.capacity = size
-
-	// Adjust hot/cold split proportionally
-	ratio := float64(c.state.hotCapacity) / float64(oldCapacity)
-	newHotCapacity := int(float64(size) * ratio)
-	if newHotCapacity == 0 {
-		newHotCapacity = 1
-	}
-	if newHotCapacity >= size {
-		newHotCapacity = size - 1
+	newPage := &page[K, V]{key: key, value: value, expiresAt: expiresAt}
+	c.altPages[key] = newPage
+	evicted := c.policy.Insert(newPage)
+	for _, e := range evicted {
+		delete(c.altPages, e.key)
 	}
+	c.mu.Unlock()
 
-	c.state.hotCapacity = newHotCapacity
-	c.state.coldCapacity = size - newHotCapacity
-	c.state.metaCapacity = size
+	c.altEvictions.Add(int64(len(evicted)))
+	c.fireEvicted(evicted, ReasonCapacity)
+}
 
-	// Evict excess entries if capacity decreased
-	for c.state.hotList.size+c.state.coldList.size > size {
-		if c.state.coldList.size > 0 {
-			c.state.evictColdPage()
-		} else {
-			c.state.evictHotPage()
+// SetSize adjusts the total capacity of the cache, in the same units (items
+// or cost) the cache was created with.
+func (c *Cache[K, V]) SetSize(size int64) {
+	if c.state == nil {
+		c.mu.Lock()
+		evicted := c.policy.Resize(int(size))
+		for _, e := range evicted {
+			delete(c.altPages, e.key)
 		}
+		c.mu.Unlock()
+
+		c.altEvictions.Add(int64(len(evicted)))
+		c.fireEvicted(evicted, ReasonResize)
+		return
 	}
 
-	// Maintain metadata capacity
-	c.state.maintainMetaCapacity()
+	c.mu.Lock()
+	c.state.resize(size)
+	evicts, promotes, demotes := c.state.takeEvents()
+	c.mu.Unlock()
+
+	c.fireEvents(evicts, promotes, demotes)
+}
+
+// fireEvicted invokes OnEvict for a batch of pages dropped by the
+// alt-policy path. Must be called without c.mu held.
+func (c *Cache[K, V]) fireEvicted(evicted []*page[K, V], reason EvictReason) {
+	if c.onEvict == nil {
+		return
+	}
+	for _, p := range evicted {
+		c.onEvict(p.key, p.value, reason)
+	}
 }
@@ -22,7 +22,7 @@ func TestNew(t *testing.T) {
 			if cache == nil {
 				t.Fatal("New() returned nil")
 			}
-			if cache.state.capacity != tt.expected {
+			if cache.state.capacity != int64(tt.expected) {
 				t.Errorf("New(%d) capacity = %d, want %d",
 					tt.size, cache.state.capacity, tt.expected)
 			}
@@ -76,7 +76,7 @@ func TestCacheEviction(t *testing.T) {
 
 	// Check that total resident pages doesn't exceed capacity
 	totalResident := cache.state.hotList.size + cache.state.coldList.size
-	if totalResident > cache.state.capacity {
+	if int64(totalResident) > cache.state.capacity {
 		t.Errorf("Total resident pages %d exceeds capacity %d",
 			totalResident, cache.state.capacity)
 	}
@@ -104,13 +104,13 @@ func TestHotColdTransitions(t *testing.T) {
 	}
 
 	// Check capacity constraints
-	if cache.state.hotList.size > cache.state.hotCapacity {
+	if int64(cache.state.hotList.size) > cache.state.hotCapacity {
 		t.Errorf("Hot list size %d exceeds hot capacity %d",
 			cache.state.hotList.size, cache.state.hotCapacity)
 	}
 
 	residentPages := cache.state.hotList.size + cache.state.coldList.size
-	if residentPages > cache.state.capacity {
+	if int64(residentPages) > cache.state.capacity {
 		t.Errorf("Resident pages %d exceed total capacity %d",
 			residentPages, cache.state.capacity)
 	}
@@ -216,7 +216,7 @@ func TestConcurrency(t *testing.T) {
 
 	// Verify cache is still in consistent state
 	totalResident := cache.state.hotList.size + cache.state.coldList.size
-	if totalResident > cache.state.capacity {
+	if int64(totalResident) > cache.state.capacity {
 		t.Errorf("After concurrent access, resident pages %d exceed capacity %d",
 			totalResident, cache.state.capacity)
 	}
@@ -0,0 +1,63 @@
+package clockpro
+
+// OnEvict registers a callback fired whenever a page is evicted outright
+// (its value is gone, not just moved to another list): on capacity pressure,
+// on SetSize shrinking the cache, on TTL expiration, and when Put replaces
+// an existing key's value. fn runs after the cache's mutex is released, so
+// it may safely call back into the cache. OnEvict works the same for every
+// policy.
+func (c *Cache[K, V]) OnEvict(fn func(K, V, EvictReason)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.onEvict = fn
+	if c.state != nil {
+		c.state.hooksEnabled = true
+	}
+}
+
+// OnPromote registers a callback fired whenever a cold page is promoted to
+// hot. fn runs after the cache's mutex is released. Promotion is a
+// CLOCK-Pro concept; fn never fires on a SIEVE or S3FIFO cache.
+func (c *Cache[K, V]) OnPromote(fn func(K, V)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.onPromote = fn
+	if c.state != nil {
+		c.state.hooksEnabled = true
+	}
+}
+
+// OnDemote registers a callback fired whenever a hot page is demoted to
+// cold. fn runs after the cache's mutex is released. Demotion is a
+// CLOCK-Pro concept; fn never fires on a SIEVE or S3FIFO cache.
+func (c *Cache[K, V]) OnDemote(fn func(K, V)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.onDemote = fn
+	if c.state != nil {
+		c.state.hooksEnabled = true
+	}
+}
+
+// fireEvents invokes the registered callbacks for a batch of drained
+// lifecycle events. Must be called without c.mu held.
+func (c *Cache[K, V]) fireEvents(evicts []evictEvent[K, V], promotes, demotes []pageEvent[K, V]) {
+	if c.onEvict != nil {
+		for _, e := range evicts {
+			c.onEvict(e.key, e.value, e.reason)
+		}
+	}
+	if c.onPromote != nil {
+		for _, e := range promotes {
+			c.onPromote(e.key, e.value)
+		}
+	}
+	if c.onDemote != nil {
+		for _, e := range demotes {
+			c.onDemote(e.key, e.value)
+		}
+	}
+}
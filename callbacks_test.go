@@ -0,0 +1,84 @@
+package clockpro
+
+import "testing"
+
+func TestOnEvictFiresOnCapacityPressure(t *testing.T) {
+	cache := New[int, int](1)
+
+	var evicted []int
+	cache.OnEvict(func(k int, v int, reason EvictReason) {
+		evicted = append(evicted, k)
+		if reason != ReasonCapacity {
+			t.Errorf("reason = %v, want ReasonCapacity", reason)
+		}
+	})
+
+	cache.Put(1, 1)
+	cache.Put(2, 2) // capacity 1: forces key 1 out eventually
+
+	if len(evicted) == 0 {
+		t.Error("expected OnEvict to fire under capacity pressure")
+	}
+}
+
+func TestOnEvictFiresOnReplace(t *testing.T) {
+	cache := New[string, string](4)
+
+	var gotReason EvictReason
+	var fired bool
+	cache.OnEvict(func(k string, v string, reason EvictReason) {
+		if k == "key" && v == "old" {
+			fired = true
+			gotReason = reason
+		}
+	})
+
+	cache.Put("key", "old")
+	cache.Put("key", "new")
+
+	if !fired {
+		t.Fatal("expected OnEvict to fire for the replaced value")
+	}
+	if gotReason != ReasonReplace {
+		t.Errorf("reason = %v, want ReasonReplace", gotReason)
+	}
+}
+
+func TestOnEvictFiresOnResize(t *testing.T) {
+	cache := New[int, int](10)
+	for i := 0; i < 10; i++ {
+		cache.Put(i, i)
+	}
+
+	var reasons []EvictReason
+	cache.OnEvict(func(k, v int, reason EvictReason) {
+		reasons = append(reasons, reason)
+	})
+
+	cache.SetSize(2)
+
+	if len(reasons) == 0 {
+		t.Fatal("expected OnEvict to fire while shrinking capacity")
+	}
+	for _, r := range reasons {
+		if r != ReasonResize {
+			t.Errorf("reason = %v, want ReasonResize", r)
+		}
+	}
+}
+
+func TestOnPromoteFires(t *testing.T) {
+	cache := New[string, string](4)
+
+	var promoted []string
+	cache.OnPromote(func(k, v string) {
+		promoted = append(promoted, k)
+	})
+
+	cache.Put("key", "value")
+	cache.Get("key") // cold test-bit hit should promote to hot
+
+	if len(promoted) == 0 {
+		t.Error("expected OnPromote to fire on cold->hot promotion")
+	}
+}
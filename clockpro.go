@@ -1,6 +1,10 @@
 package clockpro
 
-import "container/list"
+import (
+	"container/list"
+	"sync/atomic"
+	"time"
+)
 
 type pageState int
 
@@ -10,14 +14,23 @@ const (
 	stateColdResident
 )
 
+// page is the unit every Policy implementation tracks. Most fields are
+// CLOCK-Pro's, but the struct is shared across policies so Cache doesn't need
+// a parallel type per policy: SIEVE reuses ref as its visited bit, and
+// S3FIFO uses freq as its 2-bit life counter. A policy only touches the
+// fields its algorithm needs.
 type page[K comparable, V any] struct {
-	key    K
-	value  V
-	state  pageState
-	ref    bool          // reference bit
-	test   bool          // test bit for cold pages
-	listID int           // which list this page belongs to (0=H, 1=R, 2=M)
-	elem   *list.Element // pointer to element in container/list for O(1) removal
+	key       K
+	value     V
+	state     pageState
+	ref       bool          // reference bit (CLOCK-Pro, SIEVE)
+	test      bool          // test bit for cold pages (CLOCK-Pro)
+	listID    int           // which list this page belongs to (0=H, 1=R, 2=M)
+	cost      int64         // charge against capacity; 1 for item-count caches
+	expiresAt int64         // unix nanos; 0 means no expiration
+	freq      uint8         // life counter, 0-3 (S3FIFO)
+	elem      *list.Element // pointer to element in container/list for O(1) removal
+	pending   bool          // resident from a ghost reload, real value not filled in yet (GetOrLoad)
 }
 
 // circularList wraps a container/list.List and tracks a hand (current element)
@@ -91,41 +104,337 @@ func (cl *circularList[K, V]) head() *page[K, V] {
 	return cl.hand.Value.(*page[K, V])
 }
 
-type clock[K comparable, V any] struct {
-	hot      *circularList[K, V]
-	cold     *circularList[K, V]
-	meta     *circularList[K, V]
-	pageMap  map[K]*page[K, V]
-	capacity int
-	hotCap   int
-	coldCap  int
-	metaCap  int
+// forEach walks every page in the list once, front to back, independent of
+// the hand. The next element is captured before f runs so f may remove the
+// current page (e.g. to demote an expired entry) without disrupting the walk.
+func (cl *circularList[K, V]) forEach(f func(p *page[K, V])) {
+	var next *list.Element
+	for e := cl.l.Front(); e != nil; e = next {
+		next = e.Next()
+		f(e.Value.(*page[K, V]))
+	}
 }
 
-func newClock[K comparable, V any](capacity int) *clock[K, V] {
+// clockProState holds the three CLOCK-Pro lists (hot, resident cold, and
+// non-resident meta) plus the adaptive capacity split between them. It is
+// the unit of sharding: Cache wraps one with a single mutex, ShardedCache
+// wraps several with independent mutexes.
+//
+// Capacity and the hot/cold split are tracked in cost units rather than item
+// counts: hotCost and coldCost are running sums of resident pages' cost,
+// kept up to date on every insert/remove so eviction never has to re-sum the
+// lists. Item-count caches (the common case) simply give every page a cost
+// of 1, making cost units and item counts coincide. The meta (ghost) list
+// holds no payload, so it is still capped by item count.
+type clockProState[K comparable, V any] struct {
+	hotList      *circularList[K, V]
+	coldList     *circularList[K, V]
+	metaList     *circularList[K, V]
+	pageMap      map[K]*page[K, V]
+	capacity     int64
+	hotCapacity  int64
+	coldCapacity int64
+	metaCapacity int
+	hotCost      int64
+	coldCost     int64
+	coster       func(K, V) int64 // nil means every page costs 1
+
+	// hooksEnabled gates recordEvict/recordPromote/recordDemote so a cache
+	// with no registered lifecycle callbacks pays no bookkeeping cost.
+	hooksEnabled    bool
+	pendingEvicts   []evictEvent[K, V]
+	pendingPromotes []pageEvent[K, V]
+	pendingDemotes  []pageEvent[K, V]
+
+	// Counters backing Cache.Stats. Unlike the lifecycle events above,
+	// these are always updated; an atomic add is cheap enough to pay
+	// unconditionally, and stats are useful even with no callbacks
+	// registered.
+	hits            atomic.Int64
+	misses          atomic.Int64
+	hotHits         atomic.Int64
+	coldHits        atomic.Int64
+	nonResidentHits atomic.Int64
+	evictions       atomic.Int64
+	promotions      atomic.Int64
+	demotions       atomic.Int64
+}
+
+// EvictReason distinguishes why OnEvict fired.
+type EvictReason int
+
+const (
+	ReasonCapacity EvictReason = iota // normal eviction to make room for a new or promoted page
+	ReasonResize                      // SetSize shrank capacity below the current resident set
+	ReasonExpire                      // the page's TTL passed
+	ReasonReplace                     // Put overwrote an existing key's value
+)
+
+type evictEvent[K comparable, V any] struct {
+	key    K
+	value  V
+	reason EvictReason
+}
+
+type pageEvent[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+func (s *clockProState[K, V]) recordEvict(p *page[K, V], reason EvictReason) {
+	s.evictions.Add(1)
+	if !s.hooksEnabled {
+		return
+	}
+	s.pendingEvicts = append(s.pendingEvicts, evictEvent[K, V]{p.key, p.value, reason})
+}
+
+func (s *clockProState[K, V]) recordPromote(p *page[K, V]) {
+	s.promotions.Add(1)
+	if !s.hooksEnabled {
+		return
+	}
+	s.pendingPromotes = append(s.pendingPromotes, pageEvent[K, V]{p.key, p.value})
+}
+
+func (s *clockProState[K, V]) recordDemote(p *page[K, V]) {
+	s.demotions.Add(1)
+	if !s.hooksEnabled {
+		return
+	}
+	s.pendingDemotes = append(s.pendingDemotes, pageEvent[K, V]{p.key, p.value})
+}
+
+// takeEvents drains and returns all queued lifecycle events, so the caller
+// can fire callbacks after releasing the cache's mutex.
+func (s *clockProState[K, V]) takeEvents() (evicts []evictEvent[K, V], promotes, demotes []pageEvent[K, V]) {
+	evicts, s.pendingEvicts = s.pendingEvicts, nil
+	promotes, s.pendingPromotes = s.pendingPromotes, nil
+	demotes, s.pendingDemotes = s.pendingDemotes, nil
+	return
+}
+
+func newClockProState[K comparable, V any](capacity int64, coster func(K, V) int64) *clockProState[K, V] {
 	if capacity <= 0 {
 		capacity = 1
 	}
 
-	hotCap := capacity >> 1
-	if hotCap == 0 {
-		hotCap = 1
+	hotCapacity := capacity >> 1
+	if hotCapacity == 0 {
+		hotCapacity = 1
+	}
+	coldCapacity := capacity - hotCapacity
+
+	return &clockProState[K, V]{
+		hotList:      newCircularList[K, V](),
+		coldList:     newCircularList[K, V](),
+		metaList:     newCircularList[K, V](),
+		pageMap:      make(map[K]*page[K, V]),
+		capacity:     capacity,
+		hotCapacity:  hotCapacity,
+		coldCapacity: coldCapacity,
+		metaCapacity: metaCapacityFor(capacity, coster),
+		coster:       coster,
+	}
+}
+
+// defaultGhostCapacity bounds the meta (ghost) list for a cost-weighted
+// cache, where capacity is denominated in cost units (e.g. bytes) that bear
+// no fixed relationship to the number of resident items. Ghost entries are
+// bare placeholders regardless of their original payload's cost, so sizing
+// the list from the raw cost-capacity (as an item-count cache correctly
+// does) could let it grow orders of magnitude larger than the resident set
+// it's meant to inform.
+const defaultGhostCapacity = 10000
+
+// metaCapacityFor returns the ghost list's item-count cap: capacity itself
+// for an item-count cache (coster nil, so capacity already counts items),
+// or a fixed estimate for a cost-weighted one.
+func metaCapacityFor[K comparable, V any](capacity int64, coster func(K, V) int64) int {
+	if coster != nil {
+		return defaultGhostCapacity
+	}
+	return int(capacity)
+}
+
+// costOf returns the charge a key/value pair places on capacity: 1 when no
+// coster is configured, otherwise coster(key, value) clamped to be
+// non-negative.
+func (s *clockProState[K, V]) costOf(key K, value V) int64 {
+	if s.coster == nil {
+		return 1
+	}
+	return clampCost(s.coster(key, value))
+}
+
+// clampCost floors a cost to 0. A negative cost would drive hotCost/coldCost
+// negative, letting makeSpace admit new entries without evicting until
+// enough positive cost accumulates to pay off the debt, so every path that
+// charges capacity clamps through here, the same as costOf already did for
+// a coster's result.
+func clampCost(cost int64) int64 {
+	if cost > 0 {
+		return cost
+	}
+	return 0
+}
+
+// get looks up key, updating access metadata the same way Cache.Get does.
+// The returned bool mirrors Cache.Get's semantics: a non-resident cold hit
+// promotes the page but is still reported as a miss, since no value is held.
+// An expired resident page is treated the same way: reported as a miss and
+// demoted to the meta list rather than returned. A page left pending by a
+// ghost reload that GetOrLoad hasn't filled in yet is also reported as a
+// miss, not a hit on its placeholder zero value; see GetOrLoad.
+func (s *clockProState[K, V]) get(key K) (V, bool) {
+	p, exists := s.pageMap[key]
+	if !exists {
+		s.misses.Add(1)
+		var zero V
+		return zero, false
+	}
+
+	if s.expired(p, time.Now().UnixNano()) {
+		s.demoteExpired(p)
+		s.misses.Add(1)
+		var zero V
+		return zero, false
+	}
+
+	if p.pending {
+		s.misses.Add(1)
+		var zero V
+		return zero, false
+	}
+
+	wasNonResident := p.state == stateCold
+	wasHot := p.state == stateHot
+	s.touch(p)
+	if wasNonResident {
+		p.pending = true
+		s.misses.Add(1)
+		s.nonResidentHits.Add(1)
+		var zero V
+		return zero, false
 	}
-	coldCap := capacity - hotCap
 
-	return &clock[K, V]{
-		hot:      newCircularList[K, V](),
-		cold:     newCircularList[K, V](),
-		meta:     newCircularList[K, V](),
-		pageMap:  make(map[K]*page[K, V]),
-		capacity: capacity,
-		hotCap:   hotCap,
-		coldCap:  coldCap,
-		metaCap:  capacity,
+	s.hits.Add(1)
+	if wasHot {
+		s.hotHits.Add(1)
+	} else {
+		s.coldHits.Add(1)
 	}
+	return p.value, true
 }
 
-func (c *clock[K, V]) touch(p *page[K, V]) {
+// put inserts or updates key with an explicit cost and expiration (expiresAt
+// is a unix-nanos deadline, or 0 for no expiration), following the same
+// promotion rules as touch.
+func (s *clockProState[K, V]) put(key K, value V, cost int64, expiresAt int64) {
+	cost = clampCost(cost)
+
+	if p, exists := s.pageMap[key]; exists {
+		old := *p
+		p.value = value
+		p.pending = false
+		p.expiresAt = expiresAt
+		s.adjustCost(p, cost)
+		s.touch(p)
+		s.recordEvict(&old, ReasonReplace)
+		return
+	}
+
+	s.makeSpace(ReasonCapacity, cost)
+
+	newPage := &page[K, V]{
+		key:       key,
+		value:     value,
+		state:     stateColdResident,
+		ref:       false,
+		test:      true, // new pages start as test pages
+		listID:    1,
+		cost:      cost,
+		expiresAt: expiresAt,
+	}
+
+	s.pageMap[key] = newPage
+	s.coldList.insert(newPage)
+	s.coldCost += cost
+}
+
+// expired reports whether a resident page's TTL has passed as of now. Pages
+// already demoted to the meta list carry no value, so their expiry no
+// longer applies.
+func (s *clockProState[K, V]) expired(p *page[K, V], now int64) bool {
+	if p.state != stateHot && p.state != stateColdResident {
+		return false
+	}
+	return p.expiresAt != 0 && now >= p.expiresAt
+}
+
+// demoteExpired removes an expired resident page's value and moves it to the
+// meta list as a test-bit entry, the same place evictColdPage leaves a
+// recently-evicted test page, so a re-reference still counts as a hit on the
+// CLOCK-Pro adaptivity signal instead of looking like a brand new key.
+func (s *clockProState[K, V]) demoteExpired(p *page[K, V]) {
+	switch p.state {
+	case stateHot:
+		s.hotList.remove(p)
+		s.hotCost -= p.cost
+	case stateColdResident:
+		s.coldList.remove(p)
+		s.coldCost -= p.cost
+	default:
+		return
+	}
+
+	s.recordEvict(p, ReasonExpire)
+
+	var zero V
+	p.value = zero
+	p.state = stateCold
+	p.test = true
+	p.ref = false
+	p.listID = 2
+	p.expiresAt = 0
+	s.metaList.insert(p)
+	s.maintainMetaCapacity()
+}
+
+// sweepExpired proactively demotes every expired hot or cold-resident page
+// as of now, so memory isn't held by stale entries until someone happens to
+// Get them.
+func (s *clockProState[K, V]) sweepExpired(now int64) {
+	s.hotList.forEach(func(p *page[K, V]) {
+		if s.expired(p, now) {
+			s.demoteExpired(p)
+		}
+	})
+	s.coldList.forEach(func(p *page[K, V]) {
+		if s.expired(p, now) {
+			s.demoteExpired(p)
+		}
+	})
+}
+
+// adjustCost updates the cost sum of whichever list p currently resides in
+// to reflect a new cost, ahead of any list move touch may perform.
+func (s *clockProState[K, V]) adjustCost(p *page[K, V], newCost int64) {
+	newCost = clampCost(newCost)
+	delta := newCost - p.cost
+	p.cost = newCost
+	switch p.state {
+	case stateHot:
+		s.hotCost += delta
+	case stateColdResident:
+		s.coldCost += delta
+	}
+}
+
+// touch applies an access to page p: hot pages are marked referenced, cold
+// test pages are promoted to hot, and non-resident pages are reloaded as hot
+// (the caller is responsible for supplying a fresh value in that case).
+func (s *clockProState[K, V]) touch(p *page[K, V]) {
 	switch p.state {
 	case stateHot:
 		p.ref = true
@@ -135,11 +444,15 @@ func (c *clock[K, V]) touch(p *page[K, V]) {
 			p.state = stateHot
 			p.test = false
 			p.ref = true
-			c.cold.remove(p)
-			c.hot.insert(p)
-
-			if c.hot.size > c.hotCap {
-				c.adaptHot(false)
+			s.coldList.remove(p)
+			s.coldCost -= p.cost
+			s.hotList.insert(p)
+			s.hotCost += p.cost
+			p.listID = 0
+			s.recordPromote(p)
+
+			if s.hotCost > s.hotCapacity {
+				s.adaptHotCapacity(false)
 			}
 		} else {
 			p.ref = true
@@ -147,59 +460,98 @@ func (c *clock[K, V]) touch(p *page[K, V]) {
 
 	case stateCold:
 		if p.test {
-			c.adaptHot(true)
+			s.adaptHotCapacity(true)
 		}
 
-		c.meta.remove(p)
-		c.makeSpace()
+		s.metaList.remove(p)
+		s.makeSpace(ReasonCapacity, p.cost)
 
 		p.state = stateHot
 		p.test = false
 		p.ref = true
-		c.hot.insert(p)
+		s.hotList.insert(p)
+		s.hotCost += p.cost
+		p.listID = 0
+		s.recordPromote(p)
+	}
+}
+
+// cancelPendingReload reverts a ghost page that touch promoted to hot in
+// anticipation of GetOrLoad filling in a real value, for when the loader
+// errored instead. Without this the page would stay resident-hot forever
+// with pending still set, permanently reporting a miss on a key the cache
+// can never again treat as a fresh non-resident hit.
+func (s *clockProState[K, V]) cancelPendingReload(p *page[K, V]) {
+	if !p.pending {
+		return
 	}
+
+	s.hotList.remove(p)
+	s.hotCost -= p.cost
+
+	var zero V
+	p.value = zero
+	p.pending = false
+	p.state = stateCold
+	p.test = false
+	p.ref = false
+	p.listID = 2
+	s.metaList.insert(p)
+	s.maintainMetaCapacity()
 }
 
-func (c *clock[K, V]) adaptHot(increase bool) {
-	if increase && c.hotCap < c.capacity-1 {
-		c.hotCap++
-		c.coldCap--
-	} else if !increase && c.hotCap > 1 {
-		c.hotCap--
-		c.coldCap++
+func (s *clockProState[K, V]) adaptHotCapacity(increase bool) {
+	if increase && s.hotCapacity < s.capacity-1 {
+		s.hotCapacity++
+		s.coldCapacity--
+	} else if !increase && s.hotCapacity > 1 {
+		s.hotCapacity--
+		s.coldCapacity++
 	}
 }
 
-func (c *clock[K, V]) evictCold() *page[K, V] {
+func (s *clockProState[K, V]) evictColdPage(reason EvictReason) *page[K, V] {
 	steps := 0
-	maxSteps := c.cold.size * 2
+	maxSteps := s.coldList.size * 2
 
-	for c.cold.size > 0 && steps < maxSteps {
-		victim := c.cold.head()
+	for s.coldList.size > 0 && steps < maxSteps {
+		victim := s.coldList.head()
 		if victim == nil {
 			break
 		}
-		c.cold.moveHand()
+		s.coldList.moveHand()
 		steps++
 
 		if victim.ref {
 			victim.ref = false
 			victim.state = stateHot
-			c.cold.remove(victim)
-			c.hot.insert(victim)
+			s.coldList.remove(victim)
+			s.coldCost -= victim.cost
+			s.hotList.insert(victim)
+			s.hotCost += victim.cost
+			victim.listID = 0
+			s.recordPromote(victim)
 			continue
 		}
 
-		c.cold.remove(victim)
-		delete(c.pageMap, victim.key)
+		s.coldList.remove(victim)
+		s.coldCost -= victim.cost
+		s.recordEvict(victim, reason)
 
 		if victim.test {
+			// Demote to a ghost entry on the meta list rather than
+			// deleting it outright, so pageMap still finds it and a
+			// re-reference counts as a non-resident hit instead of
+			// looking like a brand new key.
 			var zero V
 			victim.value = zero
 			victim.state = stateCold
 			victim.test = false
-			c.meta.insert(victim)
-			c.trimMeta()
+			victim.listID = 2
+			s.metaList.insert(victim)
+			s.maintainMetaCapacity()
+		} else {
+			delete(s.pageMap, victim.key)
 		}
 
 		return victim
@@ -207,31 +559,36 @@ func (c *clock[K, V]) evictCold() *page[K, V] {
 	return nil
 }
 
-func (c *clock[K, V]) evictHot() *page[K, V] {
+func (s *clockProState[K, V]) evictHotPage(reason EvictReason) *page[K, V] {
 	steps := 0
-	maxSteps := c.hot.size * 2
+	maxSteps := s.hotList.size * 2
 
-	for c.hot.size > 0 && steps < maxSteps {
-		victim := c.hot.head()
+	for s.hotList.size > 0 && steps < maxSteps {
+		victim := s.hotList.head()
 		if victim == nil {
 			break
 		}
-		c.hot.moveHand()
+		s.hotList.moveHand()
 		steps++
 
 		if victim.ref {
 			victim.ref = false
 		} else {
-			c.hot.remove(victim)
+			s.hotList.remove(victim)
+			s.hotCost -= victim.cost
 
-			if c.cold.size < c.coldCap {
+			if s.coldCost < s.coldCapacity {
 				victim.state = stateColdResident
 				victim.test = true
 				victim.ref = false
-				c.cold.insert(victim)
-				c.adaptHot(true)
+				victim.listID = 1
+				s.coldList.insert(victim)
+				s.coldCost += victim.cost
+				s.adaptHotCapacity(true)
+				s.recordDemote(victim)
 			} else {
-				delete(c.pageMap, victim.key)
+				delete(s.pageMap, victim.key)
+				s.recordEvict(victim, reason)
 			}
 			return victim
 		}
@@ -239,25 +596,30 @@ func (c *clock[K, V]) evictHot() *page[K, V] {
 	return nil
 }
 
-func (c *clock[K, V]) trimMeta() {
-	for c.meta.size > c.metaCap {
-		victim := c.meta.head()
+func (s *clockProState[K, V]) maintainMetaCapacity() {
+	for s.metaList.size > s.metaCapacity {
+		victim := s.metaList.head()
 		if victim == nil {
 			break
 		}
-		c.meta.remove(victim)
-		delete(c.pageMap, victim.key)
+		s.metaList.remove(victim)
+		delete(s.pageMap, victim.key)
 	}
 }
 
-func (c *clock[K, V]) makeSpace() {
-	for c.hot.size+c.cold.size >= c.capacity {
-		if c.cold.size > 0 {
-			if c.evictCold() == nil {
+// makeSpace evicts resident pages until the incoming page of cost
+// incomingCost will fit within capacity alongside what's already resident.
+// Checking the incoming cost too (not just the current residents') matters
+// for cost-weighted caches: a single large page can blow the budget even
+// when existing residents are already under it.
+func (s *clockProState[K, V]) makeSpace(reason EvictReason, incomingCost int64) {
+	for s.hotCost+s.coldCost+incomingCost > s.capacity {
+		if s.coldList.size > 0 {
+			if s.evictColdPage(reason) == nil {
 				break
 			}
-		} else if c.hot.size > 0 {
-			if c.evictHot() == nil {
+		} else if s.hotList.size > 0 {
+			if s.evictHotPage(reason) == nil {
 				break
 			}
 		} else {
@@ -266,38 +628,85 @@ func (c *clock[K, V]) makeSpace() {
 	}
 }
 
-func (c *clock[K, V]) resize(size int) {
+// Access, Insert, Remove, and Resize below make clockProState satisfy
+// Policy[K,V], so CLOCK-Pro is one interchangeable implementation alongside
+// SIEVE and S3FIFO (see policy.go). Cache's own Get/Put/SetSize still call
+// the richer named methods above directly, since cost, TTL, and the
+// promote/demote callbacks are CLOCK-Pro-specific; these adapters exist so
+// the algorithm itself is pluggable, per the Policy contract.
+
+// Access implements Policy by recording a hit the same way touch does.
+func (s *clockProState[K, V]) Access(p *page[K, V]) { s.touch(p) }
+
+// Insert implements Policy, making room for p and adding it as a fresh cold
+// page, the same as put does for a brand new key. It always returns nil:
+// pages it evicts are reported only through recordEvict (the eviction
+// counter and, with callbacks registered, the event queue).
+func (s *clockProState[K, V]) Insert(p *page[K, V]) []*page[K, V] {
+	s.makeSpace(ReasonCapacity, p.cost)
+
+	p.state = stateColdResident
+	p.ref = false
+	p.test = true
+	p.listID = 1
+	s.pageMap[p.key] = p
+	s.coldList.insert(p)
+	s.coldCost += p.cost
+	return nil
+}
+
+// Remove implements Policy, dropping p's key from whichever list it is on.
+func (s *clockProState[K, V]) Remove(p *page[K, V]) {
+	switch p.state {
+	case stateHot:
+		s.hotList.remove(p)
+		s.hotCost -= p.cost
+	case stateColdResident:
+		s.coldList.remove(p)
+		s.coldCost -= p.cost
+	case stateCold:
+		s.metaList.remove(p)
+	}
+	delete(s.pageMap, p.key)
+}
+
+// Resize implements Policy by delegating to resize. Like Insert, it always
+// returns nil; evicted pages are reported only through recordEvict.
+func (s *clockProState[K, V]) Resize(n int) []*page[K, V] {
+	s.resize(int64(n))
+	return nil
+}
+
+func (s *clockProState[K, V]) resize(size int64) {
 	if size <= 0 {
 		size = 1
 	}
 
-	oldCap := c.capacity
-	c.capacity = size
+	oldCapacity := s.capacity
+	s.capacity = size
 
-	ratio := (c.hotCap * 1000) / oldCap
-	newHotCap := (size * ratio) / 1000
-	if newHotCap == 0 {
-		newHotCap = 1
+	ratio := (s.hotCapacity * 1000) / oldCapacity
+	newHotCapacity := (size * ratio) / 1000
+	if newHotCapacity == 0 {
+		newHotCapacity = 1
 	}
-	if newHotCap >= size {
-		newHotCap = size - 1
+	if newHotCapacity >= size {
+		newHotCapacity = size - 1
 	}
 
-	c.hotCap = newHotCap
-	c.coldCap = size - newHotCap
-	c.metaCap = size
+	s.hotCapacity = newHotCapacity
+	s.coldCapacity = size - newHotCapacity
+	s.metaCapacity = metaCapacityFor(size, s.coster)
 
-	for c.hot.size+c.cold.size > size {
+	for s.hotCost+s.coldCost > size {
 		evicted := false
-		if c.cold.size > 0 {
-			victim := c.evictCold()
-			if victim != nil {
+		if s.coldList.size > 0 {
+			if s.evictColdPage(ReasonResize) != nil {
 				evicted = true
 			}
 		}
-		if !evicted && c.hot.size > 0 {
-			victim := c.evictHot()
-			if victim != nil {
+		if !evicted && s.hotList.size > 0 {
+			if s.evictHotPage(ReasonResize) != nil {
 				evicted = true
 			}
 		}
@@ -306,5 +715,5 @@ func (c *clock[K, V]) resize(size int) {
 		}
 	}
 
-	c.trimMeta()
+	s.maintainMetaCapacity()
 }
@@ -0,0 +1,84 @@
+package clockpro
+
+import "testing"
+
+func TestNewWithCostUsesWeight(t *testing.T) {
+	cache := NewWithCost[string, string](10, func(_ string, v string) int64 {
+		return int64(len(v))
+	})
+
+	cache.Put("a", "12345") // cost 5
+	cache.Put("b", "12345") // cost 5, total 10, at capacity
+
+	if _, ok := cache.Get("a"); !ok {
+		t.Error("expected a to be resident")
+	}
+	if _, ok := cache.Get("b"); !ok {
+		t.Error("expected b to be resident")
+	}
+
+	// A third entry should force eviction to stay within the cost budget.
+	cache.Put("c", "1234567890") // cost 10
+
+	if cache.state.hotCost+cache.state.coldCost > cache.state.capacity {
+		t.Errorf("resident cost %d exceeds capacity %d",
+			cache.state.hotCost+cache.state.coldCost, cache.state.capacity)
+	}
+}
+
+func TestPutWithCostOverridesCoster(t *testing.T) {
+	cache := NewWithCost[string, string](100, func(_ string, v string) int64 {
+		return int64(len(v))
+	})
+
+	cache.PutWithCost("key", "value", 42)
+
+	p := cache.state.pageMap["key"]
+	if p == nil {
+		t.Fatal("expected key to be present")
+	}
+	if p.cost != 42 {
+		t.Errorf("cost = %d, want 42", p.cost)
+	}
+}
+
+func TestPutWithCostClampsNegativeCost(t *testing.T) {
+	cache := NewWithCost[string, string](10, func(_ string, v string) int64 {
+		return int64(len(v))
+	})
+
+	cache.PutWithCost("key", "value", -100)
+
+	p := cache.state.pageMap["key"]
+	if p == nil {
+		t.Fatal("expected key to be present")
+	}
+	if p.cost != 0 {
+		t.Errorf("cost = %d, want 0 (negative cost should clamp)", p.cost)
+	}
+
+	// A negative cost must not let the cache hold more than capacity's worth
+	// of other entries without eviction.
+	for i := 0; i < 20; i++ {
+		cache.Put(string(rune('a'+i)), "1234567890") // cost 10 each
+	}
+	if cache.state.hotCost+cache.state.coldCost > cache.state.capacity {
+		t.Errorf("resident cost %d exceeds capacity %d",
+			cache.state.hotCost+cache.state.coldCost, cache.state.capacity)
+	}
+}
+
+func TestCostAdaptHotCapacity(t *testing.T) {
+	cache := New[int, int](10)
+
+	initial := cache.state.hotCapacity
+	cache.Put(1, 1)
+	for i := 0; i < 3; i++ {
+		cache.Get(1)
+	}
+
+	if cache.state.hotCapacity < 1 {
+		t.Error("hot capacity should never drop below 1")
+	}
+	_ = initial
+}
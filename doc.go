@@ -15,6 +15,18 @@
 //	value, found := cache.Get("key1")
 //	cache.SetSize(2000) // adjust capacity
 //
+// For entries of varying size, NewWithCost charges capacity by weight
+// instead of item count:
+//
+//	cache := clockpro.NewWithCost[string, []byte](1<<20, func(_ string, v []byte) int64 {
+//		return int64(len(v))
+//	})
+//
 // The implementation follows the CLOCK-Pro design from the 2005 USENIX ATC paper
 // by Song Jiang & Xiaodong Zhang.
+//
+// WithPolicy swaps CLOCK-Pro for a different eviction algorithm, for
+// workloads that don't need its adaptive hot/cold split:
+//
+//	cache := clockpro.New[string, string](1000, clockpro.WithPolicy(clockpro.SIEVE))
 package clockpro
@@ -0,0 +1,108 @@
+package clockpro
+
+import "sync"
+
+// callGroup coalesces concurrent calls for the same key into one execution,
+// the same role golang.org/x/sync/singleflight.Group plays for GetOrLoad: a
+// stampede of readers that all miss on the same key triggers exactly one
+// loader call, and every other caller blocks on that call's result. The rest
+// of this package has no external dependencies, so this is a small
+// hand-rolled equivalent rather than a new import.
+type callGroup[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*inflightCall[V]
+}
+
+type inflightCall[V any] struct {
+	wg  sync.WaitGroup
+	val V
+	err error
+}
+
+// do runs fn for key, or waits for and returns the result of an already
+// in-flight call for the same key.
+func (g *callGroup[K, V]) do(key K, fn func() (V, error)) (V, error) {
+	g.mu.Lock()
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.val, c.err
+	}
+
+	c := new(inflightCall[V])
+	c.wg.Add(1)
+	if g.calls == nil {
+		g.calls = make(map[K]*inflightCall[V])
+	}
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	c.val, c.err = fn()
+	c.wg.Done()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+
+	return c.val, c.err
+}
+
+// GetOrLoad returns the cached value for key, calling loader to populate the
+// cache on a miss. Concurrent misses on the same key are coalesced: only one
+// goroutine calls loader, and the rest block on its result. On error nothing
+// is inserted.
+//
+// On a CLOCK-Pro cache, a miss on a non-resident (ghost) key already
+// promotes the page to hot inside Get, including the adaptHotCapacity
+// signal that the ghost's test bit carries — it just can't supply a value,
+// since none is stored for non-resident pages. GetOrLoad reuses that
+// promotion by filling in the loaded value directly rather than issuing a
+// fresh Put, which would treat the key as brand new and lose the adaptivity
+// signal Get already acted on. The promoted page is marked pending until
+// that fill-in happens, so a plain Get or Put from another goroutine on the
+// same key sees a miss (or overwrites it outright) instead of a false hit
+// on the placeholder zero value; a loader error reverts the page to
+// non-resident rather than leaving it stuck pending. SIEVE and S3FIFO
+// caches have no such in-flight page to fill in, so GetOrLoad just calls
+// Put for them; S3FIFO still recognizes a key it recently evicted from its
+// small FIFO and promotes it straight to main.
+func (c *Cache[K, V]) GetOrLoad(key K, loader func(K) (V, error)) (V, error) {
+	if v, ok := c.Get(key); ok {
+		return v, nil
+	}
+
+	v, err := c.loaders.do(key, func() (V, error) {
+		return loader(key)
+	})
+	if err != nil {
+		if c.state != nil {
+			c.mu.Lock()
+			if p, exists := c.state.pageMap[key]; exists {
+				c.state.cancelPendingReload(p)
+			}
+			c.mu.Unlock()
+		}
+		var zero V
+		return zero, err
+	}
+
+	if c.state == nil {
+		c.Put(key, v)
+		return v, nil
+	}
+
+	c.mu.Lock()
+	if p, exists := c.state.pageMap[key]; exists {
+		p.value = v
+		p.pending = false
+		p.expiresAt = expiryFor(c.defaultTTL)
+		c.state.adjustCost(p, c.state.costOf(key, v))
+	} else {
+		c.state.put(key, v, c.state.costOf(key, v), expiryFor(c.defaultTTL))
+	}
+	evicts, promotes, demotes := c.state.takeEvents()
+	c.mu.Unlock()
+
+	c.fireEvents(evicts, promotes, demotes)
+	return v, nil
+}
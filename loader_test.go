@@ -0,0 +1,167 @@
+package clockpro
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetOrLoadMiss(t *testing.T) {
+	cache := New[string, string](4)
+	var calls int32
+
+	val, err := cache.GetOrLoad("key", func(k string) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		return "value-" + k, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if val != "value-key" {
+		t.Errorf("GetOrLoad returned %q, want value-key", val)
+	}
+	if calls != 1 {
+		t.Errorf("loader called %d times, want 1", calls)
+	}
+
+	// Now resident, GetOrLoad should not call the loader again.
+	val, err = cache.GetOrLoad("key", func(k string) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		return "wrong", nil
+	})
+	if err != nil || val != "value-key" {
+		t.Errorf("GetOrLoad on hit = (%v, %v), want (value-key, nil)", val, err)
+	}
+	if calls != 1 {
+		t.Errorf("loader called %d times after hit, want 1", calls)
+	}
+}
+
+func TestGetOrLoadCoalescesConcurrentMisses(t *testing.T) {
+	cache := New[string, int](4)
+	var calls int32
+	release := make(chan struct{})
+
+	loader := func(k string) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return 42, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]int, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, err := cache.GetOrLoad("shared", loader)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			results[i] = v
+		}(i)
+	}
+
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("loader called %d times for concurrent misses, want 1", calls)
+	}
+	for i, v := range results {
+		if v != 42 {
+			t.Errorf("results[%d] = %d, want 42", i, v)
+		}
+	}
+}
+
+func TestGetOrLoadGhostReloadHiddenFromConcurrentGet(t *testing.T) {
+	cache := New[string, string](1)
+	cache.Put("a", "orig")
+	cache.Put("b", "orig") // evicts a to the meta (ghost) list
+
+	release := make(chan struct{})
+	loaderStarted := make(chan struct{})
+	var loadErr error
+	var loadVal string
+	done := make(chan struct{})
+
+	go func() {
+		loadVal, loadErr = cache.GetOrLoad("a", func(k string) (string, error) {
+			close(loaderStarted)
+			<-release
+			return "reloaded", nil
+		})
+		close(done)
+	}()
+
+	<-loaderStarted
+	if v, ok := cache.Get("a"); ok {
+		t.Errorf("concurrent Get during in-flight reload = (%q, true), want a miss", v)
+	}
+
+	close(release)
+	<-done
+
+	if loadErr != nil || loadVal != "reloaded" {
+		t.Errorf("GetOrLoad = (%q, %v), want (reloaded, nil)", loadVal, loadErr)
+	}
+	if v, ok := cache.Get("a"); !ok || v != "reloaded" {
+		t.Errorf("Get after reload = (%q, %v), want (reloaded, true)", v, ok)
+	}
+}
+
+func TestGetOrLoadErrorRevertsGhostPromotion(t *testing.T) {
+	cache := New[string, string](1)
+	cache.Put("a", "orig")
+	cache.Put("b", "orig") // evicts a to the meta (ghost) list
+	wantErr := errors.New("load failed")
+
+	if _, err := cache.GetOrLoad("a", func(k string) (string, error) {
+		return "", wantErr
+	}); !errors.Is(err, wantErr) {
+		t.Errorf("err = %v, want %v", err, wantErr)
+	}
+
+	if v, ok := cache.Get("a"); ok {
+		t.Errorf("Get after failed reload = (%q, true), want a miss", v)
+	}
+}
+
+func TestGetOrLoadGhostReloadResetsExpiry(t *testing.T) {
+	cache := New[string, string](1)
+	cache.SetDefaultTTL(20 * time.Millisecond)
+	cache.Put("a", "orig")
+	cache.Put("b", "orig") // evicts a to the meta (ghost) list with its old deadline intact
+
+	time.Sleep(40 * time.Millisecond) // past a's stale pre-eviction deadline
+
+	val, err := cache.GetOrLoad("a", func(k string) (string, error) {
+		return "reloaded", nil
+	})
+	if err != nil || val != "reloaded" {
+		t.Fatalf("GetOrLoad = (%q, %v), want (reloaded, nil)", val, err)
+	}
+
+	if v, ok := cache.Get("a"); !ok || v != "reloaded" {
+		t.Errorf("Get right after reload = (%q, %v), want (reloaded, true)", v, ok)
+	}
+}
+
+func TestGetOrLoadError(t *testing.T) {
+	cache := New[string, string](4)
+	wantErr := errors.New("load failed")
+
+	_, err := cache.GetOrLoad("key", func(k string) (string, error) {
+		return "", wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Errorf("err = %v, want %v", err, wantErr)
+	}
+
+	if _, ok := cache.Get("key"); ok {
+		t.Error("failed load should not insert into the cache")
+	}
+}
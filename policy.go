@@ -0,0 +1,57 @@
+package clockpro
+
+// Policy decides which resident pages a cache keeps and which it evicts.
+// CLOCK-Pro (clockProState, see clockpro.go) is one implementation; SIEVE
+// and S3FIFO trade its adaptive hot/cold split for simpler, cheaper rules
+// that suit different workloads. Remove takes the page itself rather than a
+// bare key, since every caller already holds it.
+type Policy[K comparable, V any] interface {
+	// Access records a hit on a page the policy already tracks.
+	Access(p *page[K, V])
+	// Insert adds a newly-resident page, evicting and returning whatever
+	// the policy must drop to make room for it.
+	Insert(p *page[K, V]) (evicted []*page[K, V])
+	// Remove drops p from the policy's bookkeeping entirely.
+	Remove(p *page[K, V])
+	// Resize changes capacity to n, evicting and returning pages if the
+	// new size is smaller than the current resident set.
+	Resize(n int) (evicted []*page[K, V])
+}
+
+var (
+	_ Policy[int, int] = (*clockProState[int, int])(nil)
+	_ Policy[int, int] = (*sievePolicy[int, int])(nil)
+	_ Policy[int, int] = (*s3fifoPolicy[int, int])(nil)
+)
+
+// PolicyKind selects which Policy implementation New builds.
+type PolicyKind int
+
+const (
+	// ClockPro is the default: an adaptive hot/cold split with a
+	// non-resident ghost list feeding capacity tuning.
+	ClockPro PolicyKind = iota
+	// SIEVE is a single FIFO queue with a scanning hand that clears
+	// visited entries as it passes, evicting the first unvisited one.
+	SIEVE
+	// S3FIFO splits capacity into a small admission FIFO and a main FIFO
+	// with a 3-life scanning hand; entries that overflow small are either
+	// promoted to main (if visited) or recorded in a ghost queue whose
+	// hits promote straight into main.
+	S3FIFO
+)
+
+type options struct {
+	policy PolicyKind
+}
+
+// Option configures a Cache at construction time.
+type Option func(*options)
+
+// WithPolicy selects the eviction policy New builds in place of the default
+// CLOCK-Pro. Cost-weighting and the OnPromote/OnDemote callbacks remain
+// CLOCK-Pro-specific; a SIEVE or S3FIFO cache still supports Get, Put,
+// SetSize, TTL, and OnEvict.
+func WithPolicy(kind PolicyKind) Option {
+	return func(o *options) { o.policy = kind }
+}
@@ -0,0 +1,120 @@
+package clockpro
+
+import "testing"
+
+func TestSieveBasicOperations(t *testing.T) {
+	cache := New[string, string](2, WithPolicy(SIEVE))
+
+	cache.Put("a", "1")
+	cache.Put("b", "2")
+
+	if v, ok := cache.Get("a"); !ok || v != "1" {
+		t.Errorf("Get(a) = %q, %v; want 1, true", v, ok)
+	}
+
+	cache.Put("c", "3") // over capacity: evicts the unvisited entry
+
+	if _, ok := cache.Get("a"); !ok {
+		t.Error("expected a (visited) to survive eviction")
+	}
+	if _, ok := cache.Get("b"); ok {
+		t.Error("expected b (never visited) to be evicted")
+	}
+	if _, ok := cache.Get("c"); !ok {
+		t.Error("expected c to be resident")
+	}
+}
+
+func TestSieveSetSize(t *testing.T) {
+	cache := New[int, int](4, WithPolicy(SIEVE))
+	for i := 0; i < 4; i++ {
+		cache.Put(i, i)
+	}
+
+	cache.SetSize(1)
+
+	resident := 0
+	for i := 0; i < 4; i++ {
+		if _, ok := cache.Get(i); ok {
+			resident++
+		}
+	}
+	if resident > 1 {
+		t.Errorf("resident = %d, want at most 1 after shrinking to size 1", resident)
+	}
+}
+
+func TestSieveOnEvictFires(t *testing.T) {
+	cache := New[int, int](1, WithPolicy(SIEVE))
+
+	var evicted []int
+	cache.OnEvict(func(k, v int, reason EvictReason) {
+		evicted = append(evicted, k)
+		if reason != ReasonCapacity {
+			t.Errorf("reason = %v, want ReasonCapacity", reason)
+		}
+	})
+
+	cache.Put(1, 1)
+	cache.Put(2, 2)
+
+	if len(evicted) == 0 {
+		t.Error("expected OnEvict to fire under capacity pressure")
+	}
+}
+
+func TestS3FIFOBasicOperations(t *testing.T) {
+	cache := New[string, string](20, WithPolicy(S3FIFO))
+
+	cache.Put("a", "1")
+	if v, ok := cache.Get("a"); !ok || v != "1" {
+		t.Errorf("Get(a) = %q, %v; want 1, true", v, ok)
+	}
+
+	cache.Put("a", "2")
+	if v, ok := cache.Get("a"); !ok || v != "2" {
+		t.Errorf("Get(a) after replace = %q, %v; want 2, true", v, ok)
+	}
+}
+
+func TestS3FIFOGhostPromotesToMain(t *testing.T) {
+	cache := New[int, int](10, WithPolicy(S3FIFO))
+	policy := cache.policy.(*s3fifoPolicy[int, int])
+
+	// Fill and overflow small (capacity 1) without visiting key 1, so it
+	// evicts straight to the ghost queue.
+	cache.Put(1, 1)
+	for i := 2; i <= 3; i++ {
+		cache.Put(i, i)
+	}
+	if _, isGhost := policy.ghost[1]; !isGhost {
+		t.Fatal("expected key 1 to be evicted to the ghost queue")
+	}
+
+	cache.Put(1, 100)
+	if _, isGhost := policy.ghost[1]; isGhost {
+		t.Error("expected key 1 to leave the ghost queue once reinserted")
+	}
+	if policy.main.size == 0 {
+		t.Error("expected a ghost hit to be inserted straight into main")
+	}
+}
+
+func TestS3FIFOSetSize(t *testing.T) {
+	cache := New[int, int](10, WithPolicy(S3FIFO))
+	for i := 0; i < 10; i++ {
+		cache.Put(i, i)
+	}
+
+	cache.SetSize(2)
+
+	resident := 0
+	for i := 0; i < 10; i++ {
+		if _, ok := cache.Get(i); ok {
+			resident++
+		}
+	}
+	if resident > 2 {
+		t.Errorf("resident = %d, want at most 2 after shrinking to size 2", resident)
+	}
+}
@@ -0,0 +1,165 @@
+package clockpro
+
+// s3fifoPolicy implements Policy with S3-FIFO: a small admission FIFO
+// (roughly 10% of capacity) feeding a main FIFO (the rest). New keys enter
+// small. When small overflows, an entry that was never accessed there is
+// recorded in a ghost queue and evicted; one that was is promoted straight
+// to main. Main uses a scanning hand that gives each entry up to three
+// "lives" in its freq counter, decrementing on every scan pass instead of
+// evicting on first sight, before finally evicting it. A ghost queue hit
+// (i.e. Insert sees a key it recently evicted from small) skips small
+// entirely and goes straight to main, since the workload has shown it's
+// worth keeping.
+type s3fifoPolicy[K comparable, V any] struct {
+	small *circularList[K, V]
+	main  *circularList[K, V]
+
+	ghost    map[K]struct{}
+	ghostQ   []K
+	smallCap int
+	mainCap  int
+	ghostCap int
+}
+
+func newS3FIFOPolicy[K comparable, V any](capacity int) *s3fifoPolicy[K, V] {
+	p := &s3fifoPolicy[K, V]{
+		small: newCircularList[K, V](),
+		main:  newCircularList[K, V](),
+		ghost: make(map[K]struct{}),
+	}
+	p.setCapacity(capacity)
+	return p
+}
+
+func (p *s3fifoPolicy[K, V]) setCapacity(capacity int) {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	smallCap := capacity / 10
+	if smallCap == 0 {
+		smallCap = 1
+	}
+	mainCap := capacity - smallCap
+	if mainCap == 0 {
+		mainCap = 1
+	}
+	p.smallCap, p.mainCap, p.ghostCap = smallCap, mainCap, mainCap
+}
+
+// Access implements Policy by bumping pg's remaining lives, capped at 3.
+func (p *s3fifoPolicy[K, V]) Access(pg *page[K, V]) {
+	if pg.freq < 3 {
+		pg.freq++
+	}
+}
+
+// Insert implements Policy. A key found in the ghost queue goes straight to
+// main; any other key enters small.
+func (p *s3fifoPolicy[K, V]) Insert(pg *page[K, V]) []*page[K, V] {
+	if _, wasGhost := p.ghost[pg.key]; wasGhost {
+		p.dropGhost(pg.key)
+		return p.insertMain(pg)
+	}
+
+	pg.freq = 0
+	p.small.insert(pg)
+	return p.evictSmall()
+}
+
+// Remove implements Policy.
+func (p *s3fifoPolicy[K, V]) Remove(pg *page[K, V]) {
+	p.small.remove(pg)
+	p.main.remove(pg)
+	p.dropGhost(pg.key)
+}
+
+// Resize implements Policy.
+func (p *s3fifoPolicy[K, V]) Resize(n int) []*page[K, V] {
+	p.setCapacity(n)
+
+	var evicted []*page[K, V]
+	evicted = append(evicted, p.evictSmall()...)
+	evicted = append(evicted, p.evictMain()...)
+	return evicted
+}
+
+func (p *s3fifoPolicy[K, V]) insertMain(pg *page[K, V]) []*page[K, V] {
+	evicted := p.evictMain()
+	pg.freq = 0
+	p.main.insert(pg)
+	return evicted
+}
+
+// evictSmall pops overflow off small in FIFO order, promoting visited
+// entries to main and sending unvisited ones to the ghost queue.
+func (p *s3fifoPolicy[K, V]) evictSmall() []*page[K, V] {
+	var evicted []*page[K, V]
+	for p.small.size > p.smallCap {
+		victim := p.small.head()
+		if victim == nil {
+			break
+		}
+		p.small.moveHand()
+		p.small.remove(victim)
+
+		if victim.freq > 0 {
+			evicted = append(evicted, p.insertMain(victim)...)
+		} else {
+			p.addGhost(victim.key)
+			evicted = append(evicted, victim)
+		}
+	}
+	return evicted
+}
+
+// evictMain runs main's scanning hand, decrementing lives on each pass and
+// evicting the first entry that reaches zero.
+func (p *s3fifoPolicy[K, V]) evictMain() []*page[K, V] {
+	var evicted []*page[K, V]
+	steps := 0
+	maxSteps := p.main.size*4 + 1
+
+	for p.main.size >= p.mainCap && steps < maxSteps {
+		victim := p.main.head()
+		if victim == nil {
+			break
+		}
+		steps++
+
+		if victim.freq > 0 {
+			victim.freq--
+			p.main.moveHand()
+			continue
+		}
+
+		p.main.remove(victim)
+		evicted = append(evicted, victim)
+	}
+	return evicted
+}
+
+func (p *s3fifoPolicy[K, V]) addGhost(key K) {
+	if _, exists := p.ghost[key]; exists {
+		return
+	}
+	if len(p.ghostQ) >= p.ghostCap {
+		oldest := p.ghostQ[0]
+		p.ghostQ = p.ghostQ[1:]
+		delete(p.ghost, oldest)
+	}
+	p.ghost[key] = struct{}{}
+	p.ghostQ = append(p.ghostQ, key)
+}
+
+func (p *s3fifoPolicy[K, V]) dropGhost(key K) {
+	if _, exists := p.ghost[key]; !exists {
+		return
+	}
+	delete(p.ghost, key)
+	for i, k := range p.ghostQ {
+		if k == key {
+			p.ghostQ = append(p.ghostQ[:i], p.ghostQ[i+1:]...)
+			break
+		}
+	}
+}
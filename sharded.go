@@ -0,0 +1,191 @@
+package clockpro
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+)
+
+// ShardedCache splits the key space across N independent clockProState
+// partitions, each guarded by its own mutex, to cut lock contention under
+// concurrent access. A single Cache serializes every Get/Put through one
+// RWMutex, which becomes the bottleneck under load since even cold reads
+// take the write lock (they mutate ref bits and can promote pages). Keys are
+// routed to shards by an FNV-1a hash, computed directly off the common key
+// kinds (strings and fixed-width integers) with no allocation; any other
+// comparable K still works, falling back to hashing its string
+// representation.
+type ShardedCache[K comparable, V any] struct {
+	shards []*cacheShard[K, V]
+	mask   uint64
+}
+
+type cacheShard[K comparable, V any] struct {
+	mu    sync.RWMutex
+	state *clockProState[K, V]
+}
+
+// NewSharded returns a cache with roughly size total capacity split evenly
+// across shards partitions. shards is rounded up to the next power of two so
+// routing can use a mask instead of a modulo.
+func NewSharded[K comparable, V any](size, shards int) *ShardedCache[K, V] {
+	if shards <= 0 {
+		shards = 1
+	}
+	n := nextPowerOfTwo(shards)
+
+	sc := &ShardedCache[K, V]{
+		shards: make([]*cacheShard[K, V], n),
+		mask:   uint64(n - 1),
+	}
+
+	base, extra := size/n, size%n
+	for i := range sc.shards {
+		shardSize := base
+		if i < extra {
+			shardSize++
+		}
+		sc.shards[i] = &cacheShard[K, V]{state: newClockProState[K, V](int64(shardSize), nil)}
+	}
+
+	return sc
+}
+
+func nextPowerOfTwo(n int) int {
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
+
+func (sc *ShardedCache[K, V]) shardFor(key K) *cacheShard[K, V] {
+	return sc.shards[hashKey(key)&sc.mask]
+}
+
+// fnvOffset64 and fnvPrime64 are the FNV-1a 64-bit constants, reused across
+// hashKey's fast paths so each avoids allocating a hash.Hash.
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// hashKey FNV-1a hashes key for shard routing. Strings and the common
+// fixed-width integer kinds are hashed directly off their bytes with no
+// allocation; any other comparable K falls back to hashing its %v string
+// representation, which does allocate but keeps shardFor total for every
+// key type.
+func hashKey[K comparable](key K) uint64 {
+	switch k := any(key).(type) {
+	case string:
+		return fnv64aString(k)
+	case int:
+		return fnv64aUint64(uint64(k))
+	case int8:
+		return fnv64aUint64(uint64(k))
+	case int16:
+		return fnv64aUint64(uint64(k))
+	case int32:
+		return fnv64aUint64(uint64(k))
+	case int64:
+		return fnv64aUint64(uint64(k))
+	case uint:
+		return fnv64aUint64(uint64(k))
+	case uint8:
+		return fnv64aUint64(uint64(k))
+	case uint16:
+		return fnv64aUint64(uint64(k))
+	case uint32:
+		return fnv64aUint64(uint64(k))
+	case uint64:
+		return fnv64aUint64(k)
+	default:
+		h := fnv.New64a()
+		fmt.Fprintf(h, "%v", k)
+		return h.Sum64()
+	}
+}
+
+func fnv64aString(s string) uint64 {
+	h := uint64(fnvOffset64)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= fnvPrime64
+	}
+	return h
+}
+
+func fnv64aUint64(v uint64) uint64 {
+	h := uint64(fnvOffset64)
+	for i := 0; i < 8; i++ {
+		h ^= v & 0xff
+		h *= fnvPrime64
+		v >>= 8
+	}
+	return h
+}
+
+// Get retrieves a value from the cache, taking only the owning shard's lock.
+func (sc *ShardedCache[K, V]) Get(key K) (V, bool) {
+	s := sc.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.state.get(key)
+}
+
+// Put inserts or updates a key-value pair in the cache.
+func (sc *ShardedCache[K, V]) Put(key K, value V) {
+	s := sc.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state.put(key, value, s.state.costOf(key, value), 0)
+}
+
+// SetSize redistributes total capacity across shards proportionally, the
+// sharded equivalent of Cache.SetSize.
+func (sc *ShardedCache[K, V]) SetSize(size int) {
+	n := len(sc.shards)
+	base, extra := size/n, size%n
+
+	for i, s := range sc.shards {
+		shardSize := base
+		if i < extra {
+			shardSize++
+		}
+		s.mu.Lock()
+		s.state.resize(int64(shardSize))
+		s.mu.Unlock()
+	}
+}
+
+// ShardedStats summarizes resident/adaptive state and hit/miss counters
+// aggregated across all shards.
+type ShardedStats struct {
+	Shards   int
+	Hits     int64
+	Misses   int64
+	HotSize  int
+	ColdSize int
+	MetaSize int
+	Capacity int64
+}
+
+// Stats aggregates per-shard hit/miss and sizing counters into one summary.
+func (sc *ShardedCache[K, V]) Stats() ShardedStats {
+	st := ShardedStats{Shards: len(sc.shards)}
+
+	for _, s := range sc.shards {
+		s.mu.RLock()
+		st.Hits += s.state.hits.Load()
+		st.Misses += s.state.misses.Load()
+		st.HotSize += s.state.hotList.size
+		st.ColdSize += s.state.coldList.size
+		st.MetaSize += s.state.metaList.size
+		st.Capacity += s.state.capacity
+		s.mu.RUnlock()
+	}
+
+	return st
+}
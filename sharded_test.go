@@ -0,0 +1,90 @@
+package clockpro
+
+import "testing"
+
+func TestNewSharded(t *testing.T) {
+	tests := []struct {
+		name       string
+		size       int
+		shards     int
+		wantShards int
+	}{
+		{"power of two shards", 100, 4, 4},
+		{"rounds up to power of two", 100, 5, 8},
+		{"non-positive shards clamped to 1", 100, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := NewSharded[string, int](tt.size, tt.shards)
+			if got := len(cache.shards); got != tt.wantShards {
+				t.Errorf("NewSharded(%d, %d) shards = %d, want %d", tt.size, tt.shards, got, tt.wantShards)
+			}
+		})
+	}
+}
+
+func TestShardedCacheBasicOperations(t *testing.T) {
+	cache := NewSharded[string, string](8, 4)
+
+	if _, ok := cache.Get("key1"); ok {
+		t.Error("Get on empty cache returned ok=true")
+	}
+
+	cache.Put("key1", "value1")
+	if val, ok := cache.Get("key1"); !ok || val != "value1" {
+		t.Errorf("Get after Put: got (%v, %v), want (value1, true)", val, ok)
+	}
+
+	cache.Put("key1", "newvalue1")
+	if val, ok := cache.Get("key1"); !ok || val != "newvalue1" {
+		t.Errorf("Get after update: got (%v, %v), want (newvalue1, true)", val, ok)
+	}
+}
+
+func TestShardedCacheCapacityDistribution(t *testing.T) {
+	cache := NewSharded[int, int](10, 4)
+
+	var total int64
+	for _, s := range cache.shards {
+		total += s.state.capacity
+	}
+	if total != 10 {
+		t.Errorf("total shard capacity = %d, want 10", total)
+	}
+}
+
+func TestShardedCacheSetSize(t *testing.T) {
+	cache := NewSharded[int, int](8, 4)
+	for i := 0; i < 8; i++ {
+		cache.Put(i, i*10)
+	}
+
+	cache.SetSize(16)
+
+	var total int64
+	for _, s := range cache.shards {
+		total += s.state.capacity
+	}
+	if total != 16 {
+		t.Errorf("total shard capacity after SetSize(16) = %d, want 16", total)
+	}
+}
+
+func TestShardedCacheStats(t *testing.T) {
+	cache := NewSharded[int, int](8, 4)
+	for i := 0; i < 8; i++ {
+		cache.Put(i, i)
+	}
+
+	stats := cache.Stats()
+	if stats.Shards != 4 {
+		t.Errorf("Stats().Shards = %d, want 4", stats.Shards)
+	}
+	if stats.HotSize+stats.ColdSize == 0 {
+		t.Error("Stats() reported no resident pages after Put")
+	}
+	if stats.Capacity != 8 {
+		t.Errorf("Stats().Capacity = %d, want 8", stats.Capacity)
+	}
+}
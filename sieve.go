@@ -0,0 +1,75 @@
+package clockpro
+
+// sievePolicy implements Policy with SIEVE: a single FIFO queue and one
+// scanning hand. A hit just sets the visited bit; eviction advances the
+// hand, clearing and skipping visited entries, until it finds one that
+// isn't, which it evicts. New entries always join at the hand, never
+// jumping the queue the way CLOCK-Pro's cold-to-hot promotion does, which
+// is what makes SIEVE cheaper to maintain for workloads that don't need an
+// adaptive hot/cold split.
+type sievePolicy[K comparable, V any] struct {
+	list     *circularList[K, V]
+	capacity int
+}
+
+func newSievePolicy[K comparable, V any](capacity int) *sievePolicy[K, V] {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &sievePolicy[K, V]{list: newCircularList[K, V](), capacity: capacity}
+}
+
+// Access implements Policy by setting the visited bit.
+func (p *sievePolicy[K, V]) Access(pg *page[K, V]) {
+	pg.ref = true
+}
+
+// Insert implements Policy, evicting until there's room for one more and
+// adding pg unvisited at the hand.
+func (p *sievePolicy[K, V]) Insert(pg *page[K, V]) []*page[K, V] {
+	evicted := p.evictAbove(p.capacity - 1)
+	pg.ref = false
+	p.list.insert(pg)
+	return evicted
+}
+
+// Remove implements Policy.
+func (p *sievePolicy[K, V]) Remove(pg *page[K, V]) {
+	p.list.remove(pg)
+}
+
+// Resize implements Policy.
+func (p *sievePolicy[K, V]) Resize(n int) []*page[K, V] {
+	if n <= 0 {
+		n = 1
+	}
+	p.capacity = n
+	return p.evictAbove(p.capacity)
+}
+
+// evictAbove runs the scanning hand until the list is at or under limit,
+// clearing visited bits as it passes and evicting the first unvisited page
+// it finds at each step.
+func (p *sievePolicy[K, V]) evictAbove(limit int) []*page[K, V] {
+	var evicted []*page[K, V]
+	steps := 0
+	maxSteps := p.list.size*2 + 1
+
+	for p.list.size > limit && steps < maxSteps {
+		victim := p.list.head()
+		if victim == nil {
+			break
+		}
+		steps++
+
+		if victim.ref {
+			victim.ref = false
+			p.list.moveHand()
+			continue
+		}
+
+		p.list.remove(victim)
+		evicted = append(evicted, victim)
+	}
+	return evicted
+}
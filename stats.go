@@ -0,0 +1,106 @@
+package clockpro
+
+import "expvar"
+
+// Stats summarizes a cache's hit rate and internal sizing, so callers can
+// watch the hot/cold split adapt over time instead of tuning capacity
+// blind. HotHits, ColdHits, NonResidentHits, Promotions, Demotions, HotSize,
+// MetaSize, and HotCapacity are CLOCK-Pro specific and stay zero on a SIEVE
+// or S3FIFO cache, where ColdSize counts every resident entry; Hits, Misses,
+// Evictions, and ColdSize are populated for every policy.
+type Stats struct {
+	Hits            int64
+	Misses          int64
+	HotHits         int64
+	ColdHits        int64
+	NonResidentHits int64
+	Evictions       int64
+	Promotions      int64
+	Demotions       int64
+	HotSize         int64
+	ColdSize        int64
+	MetaSize        int64
+	HotCapacity     int64
+}
+
+// Entry is one resident key/value pair returned by Snapshot.
+type Entry[K comparable, V any] struct {
+	Key   K
+	Value V
+	Hot   bool // true for a CLOCK-Pro hot page; always false on other policies
+}
+
+// Stats reports the cache's cumulative hit/miss counters and current
+// sizing.
+func (c *Cache[K, V]) Stats() Stats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.state != nil {
+		s := c.state
+		return Stats{
+			Hits:            s.hits.Load(),
+			Misses:          s.misses.Load(),
+			HotHits:         s.hotHits.Load(),
+			ColdHits:        s.coldHits.Load(),
+			NonResidentHits: s.nonResidentHits.Load(),
+			Evictions:       s.evictions.Load(),
+			Promotions:      s.promotions.Load(),
+			Demotions:       s.demotions.Load(),
+			HotSize:         int64(s.hotList.size),
+			ColdSize:        int64(s.coldList.size),
+			MetaSize:        int64(s.metaList.size),
+			HotCapacity:     s.hotCapacity,
+		}
+	}
+
+	return Stats{
+		Hits:      c.altHits.Load(),
+		Misses:    c.altMisses.Load(),
+		Evictions: c.altEvictions.Load(),
+		ColdSize:  int64(len(c.altPages)),
+	}
+}
+
+// Snapshot returns a consistent view of every resident key at the moment it
+// is called. Non-resident (ghost) pages are excluded, since they carry no
+// value, and so is a page GetOrLoad has promoted from a ghost but not yet
+// refilled: its placeholder zero value would be indistinguishable from a
+// real one, the same reason Get reports it as a miss.
+func (c *Cache[K, V]) Snapshot() []Entry[K, V] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.state != nil {
+		entries := make([]Entry[K, V], 0, c.state.hotList.size+c.state.coldList.size)
+		c.state.hotList.forEach(func(p *page[K, V]) {
+			if p.pending {
+				return
+			}
+			entries = append(entries, Entry[K, V]{Key: p.key, Value: p.value, Hot: true})
+		})
+		c.state.coldList.forEach(func(p *page[K, V]) {
+			if p.pending {
+				return
+			}
+			entries = append(entries, Entry[K, V]{Key: p.key, Value: p.value})
+		})
+		return entries
+	}
+
+	entries := make([]Entry[K, V], 0, len(c.altPages))
+	for _, p := range c.altPages {
+		entries = append(entries, Entry[K, V]{Key: p.key, Value: p.value})
+	}
+	return entries
+}
+
+// RegisterExpvar publishes the cache's Stats under name via the expvar
+// package, for operability tooling that already scrapes expvar (e.g.
+// /debug/vars). It panics if name is already registered, the same as
+// expvar.Publish.
+func (c *Cache[K, V]) RegisterExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() any {
+		return c.Stats()
+	}))
+}
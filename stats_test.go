@@ -0,0 +1,117 @@
+package clockpro
+
+import "testing"
+
+func TestStatsTracksHitsAndMisses(t *testing.T) {
+	cache := New[int, int](10)
+
+	cache.Put(1, 1)
+	cache.Get(1)    // hit
+	cache.Get(2)    // miss
+
+	st := cache.Stats()
+	if st.Hits != 1 {
+		t.Errorf("Hits = %d, want 1", st.Hits)
+	}
+	if st.Misses != 1 {
+		t.Errorf("Misses = %d, want 1", st.Misses)
+	}
+}
+
+func TestStatsNonResidentHit(t *testing.T) {
+	cache := New[int, int](1)
+
+	cache.Put(1, 1)
+	cache.Put(2, 2) // evicts 1 to the meta (ghost) list
+	cache.Get(1)    // ghost hit: reported as a miss, but counted separately
+
+	st := cache.Stats()
+	if st.NonResidentHits != 1 {
+		t.Errorf("NonResidentHits = %d, want 1", st.NonResidentHits)
+	}
+	if st.Evictions == 0 {
+		t.Error("expected at least one eviction")
+	}
+}
+
+func TestStatsSizesMatchLists(t *testing.T) {
+	cache := New[int, int](10)
+	for i := 0; i < 5; i++ {
+		cache.Put(i, i)
+	}
+
+	st := cache.Stats()
+	if st.HotSize != int64(cache.state.hotList.size) {
+		t.Errorf("HotSize = %d, want %d", st.HotSize, cache.state.hotList.size)
+	}
+	if st.ColdSize != int64(cache.state.coldList.size) {
+		t.Errorf("ColdSize = %d, want %d", st.ColdSize, cache.state.coldList.size)
+	}
+}
+
+func TestSnapshotReturnsResidentEntries(t *testing.T) {
+	cache := New[string, int](10)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+
+	entries := cache.Snapshot()
+	if len(entries) != 2 {
+		t.Fatalf("len(entries) = %d, want 2", len(entries))
+	}
+
+	seen := make(map[string]int)
+	for _, e := range entries {
+		seen[e.Key] = e.Value
+	}
+	if seen["a"] != 1 || seen["b"] != 2 {
+		t.Errorf("entries = %v, want a=1 b=2", seen)
+	}
+}
+
+func TestSnapshotExcludesPendingGhostReload(t *testing.T) {
+	cache := New[string, string](1)
+	cache.Put("a", "orig")
+	cache.Put("b", "orig") // evicts a to the meta (ghost) list
+
+	release := make(chan struct{})
+	loaderStarted := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		cache.GetOrLoad("a", func(k string) (string, error) {
+			close(loaderStarted)
+			<-release
+			return "reloaded", nil
+		})
+		close(done)
+	}()
+
+	<-loaderStarted
+	for _, e := range cache.Snapshot() {
+		if e.Key == "a" {
+			t.Errorf("Snapshot returned pending key %q mid-reload with value %q", e.Key, e.Value)
+		}
+	}
+
+	close(release)
+	<-done
+}
+
+func TestRegisterExpvarPublishesStats(t *testing.T) {
+	cache := New[int, int](10)
+	cache.Put(1, 1)
+	cache.Get(1)
+
+	cache.RegisterExpvar("TestRegisterExpvarPublishesStats")
+
+	// expvar.Publish panics on a duplicate name, confirming the first call
+	// really did register it.
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected registering the same name twice to panic")
+			}
+		}()
+		cache.RegisterExpvar("TestRegisterExpvarPublishesStats")
+	}()
+}
@@ -0,0 +1,101 @@
+package clockpro
+
+import (
+	"sync"
+	"time"
+)
+
+// PutWithTTL inserts or updates key with a custom time-to-live, overriding
+// the cache's default TTL. Once ttl has passed, Get reports a miss for the
+// key and the resident value is dropped. On a CLOCK-Pro cache the key stays
+// on the meta list so the test bit still catches a re-reference; a SIEVE or
+// S3FIFO cache forgets the key outright.
+func (c *Cache[K, V]) PutWithTTL(key K, value V, ttl time.Duration) {
+	if c.state == nil {
+		c.putAlt(key, value, expiryFor(ttl))
+		return
+	}
+
+	c.mu.Lock()
+	c.state.put(key, value, c.state.costOf(key, value), expiryFor(ttl))
+	evicts, promotes, demotes := c.state.takeEvents()
+	c.mu.Unlock()
+
+	c.fireEvents(evicts, promotes, demotes)
+}
+
+// SetDefaultTTL sets the time-to-live applied by Put and PutWithCost to
+// entries inserted or updated from now on. A zero duration (the default)
+// means entries never expire on their own.
+func (c *Cache[K, V]) SetDefaultTTL(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.defaultTTL = d
+}
+
+// StartJanitor launches a background goroutine that scans the cache every
+// interval and proactively drops expired entries, so memory isn't held by
+// stale data no one asks for. Calling the returned stop func stops the
+// goroutine and waits for it to exit.
+func (c *Cache[K, V]) StartJanitor(interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				c.sweepExpired()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
+
+// sweepExpired drops every currently-expired entry in one pass.
+func (c *Cache[K, V]) sweepExpired() {
+	if c.state != nil {
+		c.mu.Lock()
+		c.state.sweepExpired(time.Now().UnixNano())
+		evicts, promotes, demotes := c.state.takeEvents()
+		c.mu.Unlock()
+
+		c.fireEvents(evicts, promotes, demotes)
+		return
+	}
+
+	now := time.Now().UnixNano()
+	c.mu.Lock()
+	var evicted []*page[K, V]
+	for key, p := range c.altPages {
+		if p.expiresAt != 0 && now >= p.expiresAt {
+			old := *p
+			c.policy.Remove(p)
+			delete(c.altPages, key)
+			evicted = append(evicted, &old)
+		}
+	}
+	c.mu.Unlock()
+
+	c.altEvictions.Add(int64(len(evicted)))
+	c.fireEvicted(evicted, ReasonExpire)
+}
+
+func expiryFor(ttl time.Duration) int64 {
+	if ttl <= 0 {
+		return 0
+	}
+	return time.Now().Add(ttl).UnixNano()
+}
@@ -0,0 +1,57 @@
+package clockpro
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPutWithTTLExpires(t *testing.T) {
+	cache := New[string, string](4)
+
+	cache.PutWithTTL("key", "value", 10*time.Millisecond)
+	if val, ok := cache.Get("key"); !ok || val != "value" {
+		t.Fatalf("Get before expiry: got (%v, %v), want (value, true)", val, ok)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, ok := cache.Get("key"); ok {
+		t.Error("Get after expiry should report a miss")
+	}
+
+	// Key should still be tracked as a ghost entry, not forgotten outright.
+	if _, exists := cache.state.pageMap["key"]; !exists {
+		t.Error("expired key should remain on the meta list, not be forgotten")
+	}
+}
+
+func TestSetDefaultTTL(t *testing.T) {
+	cache := New[string, string](4)
+	cache.SetDefaultTTL(10 * time.Millisecond)
+
+	cache.Put("key", "value")
+	time.Sleep(20 * time.Millisecond)
+
+	if _, ok := cache.Get("key"); ok {
+		t.Error("Get after default TTL expiry should report a miss")
+	}
+}
+
+func TestStartJanitorSweepsExpired(t *testing.T) {
+	cache := New[string, string](4)
+	cache.PutWithTTL("key", "value", 5*time.Millisecond)
+
+	stop := cache.StartJanitor(5 * time.Millisecond)
+	defer stop()
+
+	time.Sleep(40 * time.Millisecond)
+
+	cache.mu.Lock()
+	p := cache.state.pageMap["key"]
+	stillResident := p != nil && p.state != stateCold
+	cache.mu.Unlock()
+
+	if stillResident {
+		t.Error("janitor should have demoted the expired entry")
+	}
+}